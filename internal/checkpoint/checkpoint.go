@@ -0,0 +1,262 @@
+// Package checkpoint captures and restores polecat session state, so a
+// polecat that needs to be torn down (or that crashes) can pick back up
+// roughly where it left off instead of starting from a blank worktree.
+//
+// A checkpoint is a tarball under mayor/checkpoints/ containing:
+//   - meta.json   - the Checkpoint struct below
+//   - diff.patch  - the worktree's staged+unstaged changes, from `git
+//     stash create`
+//   - scrollback.txt - the tmux pane's scrollback, from `tmux
+//     capture-pane -pS -`
+package checkpoint
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Checkpoint is a snapshot of one polecat session at a point in time.
+type Checkpoint struct {
+	Rig       string    `json:"rig"`
+	Polecat   string    `json:"polecat"`
+	Branch    string    `json:"branch"`
+	HEAD      string    `json:"head"`
+	Context   string    `json:"context"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Dir returns mayor/checkpoints under the town root.
+func Dir(townRoot string) string {
+	return filepath.Join(townRoot, "mayor", "checkpoints")
+}
+
+// Path returns the tarball path for a rig/polecat checkpoint taken at the
+// given time.
+func Path(townRoot, rigName, polecatName string, at time.Time) string {
+	name := fmt.Sprintf("%s-%s-%s.tar.gz", rigName, polecatName, at.Format("20060102-150405"))
+	return filepath.Join(Dir(townRoot), name)
+}
+
+// Create captures worktreePath's current branch, HEAD, uncommitted diff,
+// and tmuxSession's scrollback into a tarball at Path(...), returning
+// that path.
+func Create(townRoot, rigName, polecatName, worktreePath, tmuxSession, context string) (string, error) {
+	branch, err := gitOutput(worktreePath, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("reading branch: %w", err)
+	}
+
+	head, err := gitOutput(worktreePath, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("reading HEAD: %w", err)
+	}
+
+	diff, err := stashDiff(worktreePath)
+	if err != nil {
+		return "", fmt.Errorf("capturing diff: %w", err)
+	}
+
+	scrollback, err := tmuxScrollback(tmuxSession)
+	if err != nil {
+		// Non-fatal - a polecat with no live session still has a
+		// checkpointable worktree.
+		scrollback = ""
+	}
+
+	cp := Checkpoint{
+		Rig:       rigName,
+		Polecat:   polecatName,
+		Branch:    branch,
+		HEAD:      head,
+		Context:   context,
+		CreatedAt: time.Now(),
+	}
+
+	path := Path(townRoot, rigName, polecatName, cp.CreatedAt)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("creating checkpoint dir: %w", err)
+	}
+
+	if err := writeTarball(path, cp, diff, scrollback); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Load reads a checkpoint tarball back into its metadata, diff, and
+// scrollback.
+func Load(path string) (cp Checkpoint, diff string, scrollback string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return cp, "", "", fmt.Errorf("opening checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return cp, "", "", fmt.Errorf("reading checkpoint: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cp, "", "", fmt.Errorf("reading checkpoint entry: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return cp, "", "", fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+
+		switch hdr.Name {
+		case "meta.json":
+			if err := json.Unmarshal(buf.Bytes(), &cp); err != nil {
+				return cp, "", "", fmt.Errorf("parsing checkpoint metadata: %w", err)
+			}
+		case "diff.patch":
+			diff = buf.String()
+		case "scrollback.txt":
+			scrollback = buf.String()
+		}
+	}
+
+	return cp, diff, scrollback, nil
+}
+
+// Apply restores HEAD and the stashed diff into a freshly created
+// worktree. It resets the worktree's current branch (the fresh polecat
+// branch polecatMgr.Add just checked out) to cp.HEAD rather than
+// checking out that commit directly, so the restored polecat keeps
+// working on its own branch instead of in detached HEAD - otherwise any
+// commits it makes would be orphaned once the branch is expected to be
+// pushed.
+func Apply(worktreePath string, cp Checkpoint, diff string) error {
+	if _, err := gitOutput(worktreePath, "reset", "--hard", cp.HEAD); err != nil {
+		return fmt.Errorf("restoring HEAD: %w", err)
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		return nil
+	}
+
+	cmd := exec.Command("git", "apply", "--allow-empty")
+	cmd.Dir = worktreePath
+	cmd.Stdin = strings.NewReader(diff)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("applying stashed diff: %s", errMsg)
+		}
+		return fmt.Errorf("applying stashed diff: %w", err)
+	}
+
+	return nil
+}
+
+func stashDiff(worktreePath string) (string, error) {
+	stashRef, err := gitOutput(worktreePath, "stash", "create")
+	if err != nil {
+		return "", err
+	}
+	if stashRef == "" {
+		// Nothing staged or unstaged to stash.
+		return "", nil
+	}
+
+	return gitOutput(worktreePath, "diff", stashRef+"^", stashRef)
+}
+
+func tmuxScrollback(session string) (string, error) {
+	cmd := exec.Command("tmux", "capture-pane", "-pS", "-", "-t", session)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return "", fmt.Errorf("%s", errMsg)
+		}
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return "", fmt.Errorf("%s", errMsg)
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func writeTarball(path string, cp Checkpoint, diff, scrollback string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating checkpoint file: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	meta, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling checkpoint metadata: %w", err)
+	}
+
+	for _, entry := range []struct {
+		name string
+		data []byte
+	}{
+		{"meta.json", meta},
+		{"diff.patch", []byte(diff)},
+		{"scrollback.txt", []byte(scrollback)},
+	} {
+		hdr := &tar.Header{
+			Name: entry.name,
+			Mode: 0o644,
+			Size: int64(len(entry.data)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing %s header: %w", entry.name, err)
+		}
+		if _, err := tw.Write(entry.data); err != nil {
+			return fmt.Errorf("writing %s: %w", entry.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var daemonRespawn bool
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the long-lived Gas Town supervisor",
+	Long: `Run the long-lived Gas Town supervisor.
+
+The daemon watches tmux sessions for every polecat across every rig and
+reaps them when they exit, so a crashed Claude or tmux process doesn't
+silently leave a polecat rotting in the "working" state. With --respawn
+it also recreates the worktree and re-injects the last spawn context.
+
+It also promotes queued polecats (spawned while their rig was at
+max_working capacity) as capacity frees up, unless the queue is paused.
+
+This command blocks until interrupted (Ctrl-C or SIGTERM).`,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+func init() {
+	daemonCmd.Flags().BoolVar(&daemonRespawn, "respawn", false, "Automatically respawn crashed polecats")
+
+	rootCmd.AddCommand(daemonCmd)
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigsConfigPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+
+	g := git.NewGit(townRoot)
+	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
+
+	re := reaper.New(townRoot, rigMgr, rigsConfig, daemonRespawn)
+
+	stop := make(chan struct{})
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		close(stop)
+	}()
+
+	go runPromotionLoop(townRoot, stop)
+
+	fmt.Println("gt daemon: watching polecat sessions, Ctrl-C to stop")
+	return re.Run(stop)
+}
+
+// runPromotionLoop periodically promotes queued polecats as capacity
+// frees up, until stop is closed.
+func runPromotionLoop(townRoot string, stop <-chan struct{}) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := promoteQueued(townRoot); err != nil {
+				fmt.Printf("promoter: %v\n", err)
+			}
+		}
+	}
+}
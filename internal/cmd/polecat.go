@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var polecatCmd = &cobra.Command{
+	Use:   "polecat",
+	Short: "Manage polecats",
+}
+
+var polecatGcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Prune orphaned polecat worktrees",
+	Long: `Prune orphaned polecat worktrees.
+
+Walks 'git worktree list --porcelain' for every rig, cross-references it
+against the polecat registry, and removes any worktree that isn't a
+rig's main checkout or a known polecat's - the kind of stale worktree a
+crash mid-spawn can leave behind.`,
+	Args: cobra.NoArgs,
+	RunE: runPolecatGc,
+}
+
+func init() {
+	polecatCmd.AddCommand(polecatGcCmd)
+	rootCmd.AddCommand(polecatCmd)
+}
+
+func runPolecatGc(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigsConfigPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+
+	g := git.NewGit(townRoot)
+	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
+
+	total := 0
+	for rigName := range rigsConfig.Rigs {
+		r, err := rigMgr.GetRig(rigName)
+		if err != nil {
+			fmt.Printf("  %s: %v\n", rigName, err)
+			continue
+		}
+
+		n, err := gcRig(r)
+		if err != nil {
+			fmt.Printf("  %s: %v\n", rigName, err)
+			continue
+		}
+		total += n
+	}
+
+	fmt.Printf("Pruned %d orphaned worktree(s)\n", total)
+	return nil
+}
+
+// gcRig prunes orphaned worktrees for a single rig and returns how many
+// it removed.
+func gcRig(r *rig.Rig) (int, error) {
+	worktrees, err := listWorktrees(r.Path)
+	if err != nil {
+		return 0, fmt.Errorf("listing worktrees: %w", err)
+	}
+
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path))
+	known, err := polecatMgr.List()
+	if err != nil {
+		return 0, fmt.Errorf("listing polecats: %w", err)
+	}
+
+	knownPaths := make(map[string]bool, len(known))
+	for _, pc := range known {
+		knownPaths[pc.ClonePath] = true
+	}
+
+	removed := 0
+	for _, wt := range worktrees {
+		if wt == r.Path || knownPaths[wt] {
+			continue
+		}
+
+		fmt.Printf("  %s: pruning orphaned worktree %s\n", r.Name, wt)
+		if err := polecat.Adopt(r.Path, wt).Close(); err != nil {
+			fmt.Printf("  %s: %v\n", r.Name, err)
+			continue
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// listWorktrees parses `git worktree list --porcelain` into a list of
+// worktree paths (including the main one).
+func listWorktrees(rigPath string) ([]string, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = rigPath
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+		return nil, err
+	}
+
+	var paths []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if path, ok := strings.CutPrefix(line, "worktree "); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
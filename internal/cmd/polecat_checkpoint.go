@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var polecatCheckpointCmd = &cobra.Command{
+	Use:   "checkpoint <rig/polecat>",
+	Short: "Capture a polecat's worktree and session state",
+	Long: `Capture a polecat's worktree and session state into a tarball
+under mayor/checkpoints/: its current branch, HEAD, staged/unstaged
+diff, and tmux scrollback.
+
+Restore it into a fresh polecat with:
+
+  gt spawn <rig/polecat> --restore <checkpoint file>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolecatCheckpoint,
+}
+
+func init() {
+	polecatCmd.AddCommand(polecatCheckpointCmd)
+}
+
+func runPolecatCheckpoint(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigName, polecatName, err := parseSpawnAddress(args[0])
+	if err != nil {
+		return err
+	}
+	if polecatName == "" {
+		return fmt.Errorf("must specify rig/polecat, not just a rig")
+	}
+
+	rigsConfigPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+
+	g := git.NewGit(townRoot)
+	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
+	r, err := rigMgr.GetRig(rigName)
+	if err != nil {
+		return fmt.Errorf("rig '%s' not found", rigName)
+	}
+
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path))
+	pc, err := polecatMgr.Get(polecatName)
+	if err != nil {
+		return fmt.Errorf("getting polecat %s: %w", polecatName, err)
+	}
+
+	context := ""
+	if reg, err := reaper.LoadRegistry(townRoot); err == nil {
+		if live, _ := reg.ByPolecat(rigName, polecatName); live != nil {
+			context = live.Context
+		}
+	}
+
+	path, err := checkpoint.Create(townRoot, rigName, polecatName, pc.ClonePath, polecatName, context)
+	if err != nil {
+		return fmt.Errorf("checkpointing: %w", err)
+	}
+
+	fmt.Printf("Checkpointed %s/%s to %s\n", rigName, polecatName, path)
+	return nil
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/scheduler"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Inspect and control the spawn scheduler's queue",
+}
+
+var queueListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List polecats waiting for capacity",
+	Args:  cobra.NoArgs,
+	RunE:  runQueueList,
+}
+
+var queuePauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Stop promoting queued polecats until resumed",
+	Args:  cobra.NoArgs,
+	RunE:  runQueueSetPaused(true),
+}
+
+var queueResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume promoting queued polecats",
+	Args:  cobra.NoArgs,
+	RunE:  runQueueSetPaused(false),
+}
+
+func init() {
+	queueCmd.AddCommand(queueListCmd)
+	queueCmd.AddCommand(queuePauseCmd)
+	queueCmd.AddCommand(queueResumeCmd)
+	rootCmd.AddCommand(queueCmd)
+}
+
+func runQueueList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	q, err := scheduler.Load(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if q.Paused {
+		fmt.Println(style.Dim.Render("(queue is paused - 'gt queue resume' to continue promoting it)"))
+	}
+
+	entries := q.List()
+	if len(entries) == 0 {
+		fmt.Println("Queue is empty.")
+		return nil
+	}
+
+	for i, e := range entries {
+		fmt.Printf("%d. %s/%s  priority=%d  queued %s\n",
+			i+1, e.Rig, e.Polecat, e.Priority, e.QueuedAt.Format("2006-01-02 15:04:05"))
+	}
+
+	return nil
+}
+
+func runQueueSetPaused(paused bool) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+
+		q, err := scheduler.Load(townRoot)
+		if err != nil {
+			return err
+		}
+
+		q.SetPaused(paused)
+		if err := q.Save(); err != nil {
+			return err
+		}
+
+		if paused {
+			fmt.Println("Queue paused.")
+		} else {
+			fmt.Println("Queue resumed.")
+		}
+		return nil
+	}
+}
+
+// promoteQueued starts a session for every queued polecat that now has
+// capacity, in priority order. It's driven by `gt daemon`'s promotion
+// loop, and is the other half of the queuing spawn does when a rig is
+// at max_working.
+func promoteQueued(townRoot string) error {
+	rigsConfigPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+
+	q, err := scheduler.Load(townRoot)
+	if err != nil {
+		return err
+	}
+	if q.Paused {
+		return nil
+	}
+
+	g := git.NewGit(townRoot)
+	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
+
+	for _, e := range q.List() {
+		hasCapacity, err := scheduler.HasCapacity(townRoot, rigsConfig, rigMgr, e.Rig)
+		if err != nil || !hasCapacity {
+			continue
+		}
+
+		if err := startQueuedEntry(townRoot, rigMgr, e); err != nil {
+			fmt.Printf("promoter: %s/%s: %v\n", e.Rig, e.Polecat, err)
+			continue
+		}
+
+		q.Pop(e.Rig, e.Polecat)
+		if err := q.Save(); err != nil {
+			return err
+		}
+
+		fmt.Printf("promoter: started queued %s/%s\n", e.Rig, e.Polecat)
+	}
+
+	return nil
+}
+
+// startQueuedEntry starts the session for a polecat that spawn already
+// created (worktree, assignment) but left queued for capacity.
+func startQueuedEntry(townRoot string, rigMgr *rig.Manager, e scheduler.Entry) error {
+	r, err := rigMgr.GetRig(e.Rig)
+	if err != nil {
+		return fmt.Errorf("rig '%s' not found", e.Rig)
+	}
+
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path))
+	if err := polecatMgr.SetState(e.Polecat, polecat.StateWorking); err != nil {
+		return fmt.Errorf("marking polecat working: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	sessMgr := session.NewManager(t, r)
+	if err := sessMgr.Start(e.Polecat, session.StartOptions{}); err != nil {
+		return fmt.Errorf("starting session: %w", err)
+	}
+	time.Sleep(5 * time.Second)
+
+	if err := sessMgr.Inject(e.Polecat, e.Context); err != nil {
+		return fmt.Errorf("injecting context: %w", err)
+	}
+
+	if err := reaper.TrackSession(townRoot, e.Rig, e.Polecat, e.Polecat, e.Context); err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(reaper tracking: %v)", err)))
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var reapList bool
+
+var reapCmd = &cobra.Command{
+	Use:   "reap [rig/polecat]",
+	Short: "Inspect polecat sessions tracked by the daemon",
+	Long: `Inspect polecat sessions tracked by the daemon.
+
+'gt reap --list' shows every session currently being watched, plus the
+most recent exits. 'gt reap <rig/polecat>' shows the live/exit status of
+one specific polecat.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if reapList {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runReap,
+}
+
+func init() {
+	reapCmd.Flags().BoolVar(&reapList, "list", false, "List all tracked polecat sessions")
+
+	rootCmd.AddCommand(reapCmd)
+}
+
+func runReap(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	reg, err := reaper.LoadRegistry(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if reapList {
+		if len(reg.Live) == 0 {
+			fmt.Println("No polecat sessions currently tracked.")
+		}
+		for _, rec := range reg.Live {
+			fmt.Printf("%s  %s/%s (pid %d, started %s)\n",
+				style.Bold.Render("working"), rec.Rig, rec.Polecat, rec.PID, rec.StartedAt.Format("2006-01-02 15:04:05"))
+		}
+		if len(reg.Exited) > 0 {
+			fmt.Println("\nRecent exits:")
+			for _, exit := range reg.Exited {
+				status := "crashed"
+				if exit.Respawned {
+					status = "crashed, respawned"
+				}
+				fmt.Printf("%s  %s/%s (tmux session gone, detected %s)\n",
+					style.Dim.Render(status), exit.Rig, exit.Polecat, exit.ExitedAt.Format("2006-01-02 15:04:05"))
+			}
+		}
+		return nil
+	}
+
+	rigName, polecatName, err := parseSpawnAddress(args[0])
+	if err != nil {
+		return err
+	}
+	if polecatName == "" {
+		return fmt.Errorf("must specify rig/polecat, not just a rig")
+	}
+
+	live, lastExit := reg.ByPolecat(rigName, polecatName)
+	if live != nil {
+		fmt.Printf("%s  %s/%s (pid %d, started %s)\n",
+			style.Bold.Render("working"), rigName, polecatName, live.PID, live.StartedAt.Format("2006-01-02 15:04:05"))
+		return nil
+	}
+	if lastExit != nil {
+		suffix := ""
+		if lastExit.Respawned {
+			suffix = ", respawned"
+		}
+		fmt.Printf("%s/%s's tmux session was last seen gone at %s%s\n",
+			rigName, polecatName, lastExit.ExitedAt.Format("2006-01-02 15:04:05"), suffix)
+		return nil
+	}
+
+	fmt.Printf("%s/%s is not tracked by the daemon.\n", rigName, polecatName)
+	return nil
+}
@@ -1,23 +1,24 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"math/rand"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/checkpoint"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/reaper"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/scheduler"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tracker"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -30,9 +31,12 @@ var polecatNames = []string{
 
 // Spawn command flags
 var (
-	spawnIssue   string
-	spawnMessage string
-	spawnNoStart bool
+	spawnIssue    string
+	spawnMessage  string
+	spawnNoStart  bool
+	spawnFromSpec string
+	spawnRestore  string
+	spawnNightly  bool
 )
 
 var spawnCmd = &cobra.Command{
@@ -44,48 +48,52 @@ var spawnCmd = &cobra.Command{
 Creates a fresh polecat worktree, assigns an issue or task, and starts
 a session. Polecats are ephemeral - they exist only while working.
 
+The issue tracker backend (beads, GitHub, GitLab, or Jira) is read from
+the rig's "tracker" setting in rigs.json, so --issue accepts whatever ID
+format that backend uses (a beads ID, "GH-123", "GL-45", or a Jira key).
+
 If no polecat name is specified, generates a random name. If the specified
 name already exists as a non-working polecat, it will be replaced with
 a fresh worktree.
 
+With --from-spec, the address argument is omitted and a whole swarm of
+polecats is spawned from a JSON spec file instead: an object whose
+"polecats" list gives, per entry, a "rig", an optional "polecat" name,
+an "issue" or a "task" (in which <(ISSUE) and <(POLECAT) are
+substituted), "depends_on" (other entries' polecat names), and a
+"trigger". Each entry's "trigger" ("on-demand", "nightly",
+"any-branch", "master-only") decides whether it spawns for this run:
+pass --nightly from a scheduled job to run "nightly" entries instead of
+the default "on-demand" ones, and "master-only" entries only spawn when
+the town's checkout is on main/master.
+
 Examples:
   gt spawn gastown --issue gt-abc          # auto-generate polecat name
   gt spawn gastown/Toast --issue gt-def    # use specific name
-  gt spawn gastown/Nux -m "Fix the tests"  # free-form task`,
-	Args: cobra.ExactArgs(1),
+  gt spawn gastown/Nux -m "Fix the tests"  # free-form task
+  gt spawn --from-spec gastown/spawns.json # spawn a whole swarm`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if spawnFromSpec != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runSpawn,
 }
 
 func init() {
-	spawnCmd.Flags().StringVar(&spawnIssue, "issue", "", "Beads issue ID to assign")
+	spawnCmd.Flags().StringVar(&spawnIssue, "issue", "", "Issue ID to assign (tracker backend set per-rig)")
 	spawnCmd.Flags().StringVarP(&spawnMessage, "message", "m", "", "Free-form task description")
 	spawnCmd.Flags().BoolVar(&spawnNoStart, "no-start", false, "Assign work but don't start session")
+	spawnCmd.Flags().StringVar(&spawnFromSpec, "from-spec", "", "Spawn a swarm of polecats from a JSON spec file")
+	spawnCmd.Flags().StringVar(&spawnRestore, "restore", "", "Restore a polecat from a checkpoint file instead of starting fresh")
+	spawnCmd.Flags().BoolVar(&spawnNightly, "nightly", false, "With --from-spec, only spawn entries triggered by a scheduled nightly run")
 
 	rootCmd.AddCommand(spawnCmd)
 }
 
-// BeadsIssue represents a beads issue from JSON output.
-type BeadsIssue struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Priority    int    `json:"priority"`
-	Type        string `json:"issue_type"`
-	Status      string `json:"status"`
-}
-
 func runSpawn(cmd *cobra.Command, args []string) error {
-	if spawnIssue == "" && spawnMessage == "" {
-		return fmt.Errorf("must specify --issue or -m/--message")
-	}
-
-	// Parse address: rig/polecat or just rig
-	rigName, polecatName, err := parseSpawnAddress(args[0])
-	if err != nil {
-		return err
-	}
-
-	// Find workspace and rig
+	// Find workspace, shared by both the single-target and spec-driven paths.
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
@@ -97,17 +105,68 @@ func runSpawn(cmd *cobra.Command, args []string) error {
 		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
 	}
 
-	g := git.NewGit(townRoot)
-	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
-	r, err := rigMgr.GetRig(rigName)
+	if spawnFromSpec != "" {
+		return runSpawnFromSpec(townRoot, rigsConfig, spawnFromSpec, spawnNightly)
+	}
+
+	if spawnIssue == "" && spawnMessage == "" && spawnRestore == "" {
+		return fmt.Errorf("must specify --issue, -m/--message, or --restore")
+	}
+
+	// Parse address: rig/polecat or just rig
+	rigName, polecatName, err := parseSpawnAddress(args[0])
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found", rigName)
+		return err
+	}
+
+	return spawnOne(townRoot, rigsConfig, spawnOpts{
+		RigName:     rigName,
+		PolecatName: polecatName,
+		Issue:       spawnIssue,
+		Message:     spawnMessage,
+		NoStart:     spawnNoStart,
+		Restore:     spawnRestore,
+	})
+}
+
+// spawnOpts describes a single polecat to spawn. It's the shared shape
+// between a plain `gt spawn` invocation and each entry of a --from-spec
+// fanout.
+type spawnOpts struct {
+	RigName     string
+	PolecatName string
+	Issue       string
+	Message     string
+	NoStart     bool
+	// Restore, if set, is a checkpoint file path whose worktree diff and
+	// HEAD are applied after the fresh worktree is created, with the
+	// checkpointed context re-injected instead of a plain [SPAWN] one.
+	Restore string
+}
+
+// spawnOne creates one fresh polecat worktree, assigns it work, and
+// starts its session. It holds the whole body of what used to be
+// runSpawn, so both a plain `gt spawn` and a --from-spec fanout drive
+// the exact same path per polecat.
+func spawnOne(townRoot string, rigsConfig *config.RigsConfig, opts spawnOpts) (err error) {
+	r, err := loadRig(townRoot, rigsConfig, opts.RigName)
+	if err != nil {
+		return err
 	}
 
 	// Get polecat manager
 	polecatGit := git.NewGit(r.Path)
 	polecatMgr := polecat.NewManager(r, polecatGit)
 
+	// Resolve the rig's configured issue-tracker backend. Rigs that don't
+	// set "tracker" in rigs.json keep the historical beads behavior.
+	trk, err := tracker.New(tracker.Name(r.Tracker), r.Path)
+	if err != nil {
+		return fmt.Errorf("resolving tracker: %w", err)
+	}
+
+	polecatName := opts.PolecatName
+
 	// Ephemeral model: always create fresh polecat
 	// If no name specified, generate one
 	if polecatName == "" {
@@ -138,41 +197,122 @@ func runSpawn(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("creating polecat: %w", err)
 	}
 
-	// Initialize beads in the new worktree
-	fmt.Printf("Initializing beads in worktree...\n")
-	if err := initBeadsInWorktree(pc.ClonePath); err != nil {
-		// Non-fatal - beads might already be initialized
-		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(beads init: %v)", err)))
+	// Adopt the worktree Add just created so any failure from here on
+	// tears it down instead of leaving an orphaned worktree behind for
+	// `gt polecat gc` to find later. The deferred Close only runs when
+	// spawnOne returns an error; a spawn that succeeds (started, queued,
+	// or --no-start) keeps its worktree.
+	runner := polecat.Adopt(r.Path, pc.ClonePath)
+	defer func() {
+		if err != nil {
+			if closeErr := runner.Close(); closeErr != nil {
+				fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(cleaning up failed spawn's worktree: %v)", closeErr)))
+			}
+		}
+	}()
+
+	// Initialize the tracker in the new worktree
+	fmt.Printf("Initializing tracker in worktree...\n")
+	if err := trk.Init(pc.ClonePath); err != nil {
+		// Non-fatal - the tracker might already be initialized
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(tracker init: %v)", err)))
+	}
+
+	// If restoring from a checkpoint, apply its diff and HEAD on top of
+	// the fresh worktree now, before any work assignment happens.
+	var restoredContext string
+	if opts.Restore != "" {
+		fmt.Printf("Restoring checkpoint %s...\n", opts.Restore)
+		cp, diff, _, err := checkpoint.Load(opts.Restore)
+		if err != nil {
+			return fmt.Errorf("loading checkpoint: %w", err)
+		}
+		if err := checkpoint.Apply(pc.ClonePath, cp, diff); err != nil {
+			return fmt.Errorf("applying checkpoint: %w", err)
+		}
+		restoredContext = cp.Context
 	}
 
 	// Get issue details if specified
-	var issue *BeadsIssue
-	if spawnIssue != "" {
-		issue, err = fetchBeadsIssue(r.Path, spawnIssue)
+	var issue *tracker.Issue
+	if opts.Issue != "" {
+		issue, err = trk.FetchIssue(opts.Issue)
 		if err != nil {
-			return fmt.Errorf("fetching issue %s: %w", spawnIssue, err)
+			return fmt.Errorf("fetching issue %s: %w", opts.Issue, err)
 		}
 	}
 
 	// Assign issue/task to polecat
-	assignmentID := spawnIssue
+	assignmentID := opts.Issue
 	if assignmentID == "" {
 		assignmentID = "task:" + time.Now().Format("20060102-150405")
 	}
 	if err := polecatMgr.AssignIssue(polecatName, assignmentID); err != nil {
 		return fmt.Errorf("assigning issue: %w", err)
 	}
+	if opts.Issue != "" {
+		if err := trk.AssignIssue(opts.Issue, polecatName); err != nil {
+			// Non-fatal - the local assignment above is what spawn and
+			// session injection actually depend on.
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(tracker assign: %v)", err)))
+		}
+	}
 
 	fmt.Printf("%s Assigned %s to %s/%s\n",
 		style.Bold.Render("✓"),
-		assignmentID, rigName, polecatName)
+		assignmentID, opts.RigName, polecatName)
 
 	// Stop here if --no-start
-	if spawnNoStart {
+	if opts.NoStart {
 		fmt.Printf("\n  %s\n", style.Dim.Render("Use 'gt session start' to start the session"))
 		return nil
 	}
 
+	// If the rig or the town as a whole is already at max_working
+	// capacity, queue this polecat instead of starting a session for it.
+	// A background promoter (run by `gt daemon`) starts it once capacity
+	// frees up.
+	g := git.NewGit(townRoot)
+	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
+	hasCapacity, err := scheduler.HasCapacity(townRoot, rigsConfig, rigMgr, opts.RigName)
+	if err != nil {
+		return fmt.Errorf("checking scheduler capacity: %w", err)
+	}
+	if !hasCapacity {
+		priority := 0
+		if issue != nil {
+			priority = issue.Priority
+		}
+
+		queuedContext := buildSpawnContext(issue, opts.Message)
+		if opts.Restore != "" {
+			queuedContext = "[RESTORE] Your previous session was checkpointed and has been restored.\n\n" + restoredContext
+		}
+
+		if err := polecatMgr.SetState(polecatName, polecat.StateQueued); err != nil {
+			return fmt.Errorf("marking polecat queued: %w", err)
+		}
+
+		q, err := scheduler.Load(townRoot)
+		if err != nil {
+			return err
+		}
+		q.Enqueue(scheduler.Entry{
+			Rig:      opts.RigName,
+			Polecat:  polecatName,
+			Issue:    opts.Issue,
+			Priority: priority,
+			Context:  queuedContext,
+		})
+		if err := q.Save(); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s at capacity - queued %s/%s (use 'gt queue list' to check status)\n",
+			style.Dim.Render(opts.RigName), opts.RigName, polecatName)
+		return nil
+	}
+
 	// Start session
 	t := tmux.NewTmux()
 	sessMgr := session.NewManager(t, r)
@@ -184,7 +324,7 @@ func runSpawn(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Session already running, injecting context...\n")
 	} else {
 		// Start new session
-		fmt.Printf("Starting session for %s/%s...\n", rigName, polecatName)
+		fmt.Printf("Starting session for %s/%s...\n", opts.RigName, polecatName)
 		if err := sessMgr.Start(polecatName, session.StartOptions{}); err != nil {
 			return fmt.Errorf("starting session: %w", err)
 		}
@@ -194,19 +334,40 @@ func runSpawn(cmd *cobra.Command, args []string) error {
 	}
 
 	// Inject initial context
-	context := buildSpawnContext(issue, spawnMessage)
+	var context string
+	if opts.Restore != "" {
+		context = "[RESTORE] Your previous session was checkpointed and has been restored.\n\n" + restoredContext
+	} else {
+		context = buildSpawnContext(issue, opts.Message)
+	}
 	fmt.Printf("Injecting work assignment...\n")
 	if err := sessMgr.Inject(polecatName, context); err != nil {
 		return fmt.Errorf("injecting context: %w", err)
 	}
 
+	// Track the session's PID so `gt daemon` can reap it if it crashes.
+	if err := reaper.TrackSession(townRoot, opts.RigName, polecatName, polecatName, context); err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(reaper tracking: %v)", err)))
+	}
+
 	fmt.Printf("%s Session started. Attach with: %s\n",
 		style.Bold.Render("✓"),
-		style.Dim.Render(fmt.Sprintf("gt session at %s/%s", rigName, polecatName)))
+		style.Dim.Render(fmt.Sprintf("gt session at %s/%s", opts.RigName, polecatName)))
 
 	return nil
 }
 
+// loadRig resolves a rig by name using the town's git root and rigs config.
+func loadRig(townRoot string, rigsConfig *config.RigsConfig, rigName string) (*rig.Rig, error) {
+	g := git.NewGit(townRoot)
+	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
+	r, err := rigMgr.GetRig(rigName)
+	if err != nil {
+		return nil, fmt.Errorf("rig '%s' not found", rigName)
+	}
+	return r, nil
+}
+
 // parseSpawnAddress parses "rig/polecat" or "rig".
 func parseSpawnAddress(addr string) (rigName, polecatName string, err error) {
 	if strings.Contains(addr, "/") {
@@ -251,56 +412,8 @@ func generatePolecatName(mgr *polecat.Manager) string {
 	}
 }
 
-// initBeadsInWorktree initializes beads in a new polecat worktree.
-func initBeadsInWorktree(worktreePath string) error {
-	cmd := exec.Command("bd", "init")
-	cmd.Dir = worktreePath
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		errMsg := strings.TrimSpace(stderr.String())
-		if errMsg != "" {
-			return fmt.Errorf("%s", errMsg)
-		}
-		return err
-	}
-
-	return nil
-}
-
-// fetchBeadsIssue gets issue details from beads CLI.
-func fetchBeadsIssue(rigPath, issueID string) (*BeadsIssue, error) {
-	cmd := exec.Command("bd", "show", issueID, "--json")
-	cmd.Dir = rigPath
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		errMsg := strings.TrimSpace(stderr.String())
-		if errMsg != "" {
-			return nil, fmt.Errorf("%s", errMsg)
-		}
-		return nil, err
-	}
-
-	// bd show --json returns an array, take the first element
-	var issues []BeadsIssue
-	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
-		return nil, fmt.Errorf("parsing issue: %w", err)
-	}
-	if len(issues) == 0 {
-		return nil, fmt.Errorf("issue not found: %s", issueID)
-	}
-
-	return &issues[0], nil
-}
-
 // buildSpawnContext creates the initial context message for the polecat.
-func buildSpawnContext(issue *BeadsIssue, message string) string {
+func buildSpawnContext(issue *tracker.Issue, message string) string {
 	var sb strings.Builder
 
 	sb.WriteString("[SPAWN] You have been assigned work.\n\n")
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/specs"
+)
+
+// waveTimeout bounds how long waitForDone will wait for a wave to finish
+// before giving up. Without it, a parent that never retires and is never
+// marked crashed (e.g. its session died while no reaper was running)
+// would leave waitForDone polling forever, since such a polecat never
+// becomes "done" on its own.
+const waveTimeout = 30 * time.Minute
+
+// runSpawnFromSpec spawns a whole swarm of polecats described by the spec
+// file at path. Entries are resolved into dependency waves: every
+// polecat in a wave is spawned in parallel, and a wave doesn't start
+// until every polecat it depends on has signaled DONE.
+func runSpawnFromSpec(townRoot string, rigsConfig *config.RigsConfig, path string, nightly bool) error {
+	spec, err := specs.Load(path)
+	if err != nil {
+		return err
+	}
+
+	waves, err := specs.Waves(spec.Polecats)
+	if err != nil {
+		return err
+	}
+
+	branch, err := currentBranch(townRoot)
+	if err != nil {
+		return fmt.Errorf("determining current branch: %w", err)
+	}
+
+	for i, wave := range waves {
+		fmt.Printf("Spawning wave %d/%d (%d polecat(s))...\n", i+1, len(waves), len(wave))
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(wave))
+		for j, entry := range wave {
+			if !specs.ShouldSpawn(entry, branch, nightly) {
+				fmt.Printf("  skipping %s (trigger %q doesn't match this run)\n", entry.Addr(), entry.Trigger)
+				continue
+			}
+			wg.Add(1)
+			go func(j int, entry specs.Entry) {
+				defer wg.Done()
+				errs[j] = spawnSpecEntry(townRoot, rigsConfig, entry)
+			}(j, entry)
+		}
+		wg.Wait()
+
+		for j, err := range errs {
+			if err != nil {
+				return fmt.Errorf("spawning %s: %w", wave[j].Addr(), err)
+			}
+		}
+
+		if i == len(waves)-1 {
+			break
+		}
+		if err := waitForDone(townRoot, rigsConfig, wave); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// spawnSpecEntry spawns a single spec entry, substituting <(ISSUE)/<(POLECAT)
+// variables into its free-form task message before handing off to spawnOne.
+func spawnSpecEntry(townRoot string, rigsConfig *config.RigsConfig, entry specs.Entry) error {
+	rigName, polecatName, err := parseSpawnAddress(entry.Addr())
+	if err != nil {
+		return err
+	}
+
+	message := specs.Substitute(entry.Task, map[string]string{
+		"ISSUE":   entry.Issue,
+		"POLECAT": polecatName,
+	})
+
+	return spawnOne(townRoot, rigsConfig, spawnOpts{
+		RigName:     rigName,
+		PolecatName: polecatName,
+		Issue:       entry.Issue,
+		Message:     message,
+	})
+}
+
+// waitForDone blocks until every polecat in the wave has been retired
+// (see polecatDone), so dependents in the next wave can assume their
+// parents are finished. This is a simple poll for now; once the reaper daemon is
+// watching polecat state transitions, this can become event-driven.
+//
+// It gives up after waveTimeout and aborts the wave immediately if any
+// polecat in it is found crashed - neither can resolve on its own by
+// polling harder, so looping forever isn't an option.
+func waitForDone(townRoot string, rigsConfig *config.RigsConfig, wave []specs.Entry) error {
+	deadline := time.Now().Add(waveTimeout)
+
+	for {
+		allDone := true
+		for _, entry := range wave {
+			rigName, polecatName, err := parseSpawnAddress(entry.Addr())
+			if err != nil {
+				return err
+			}
+
+			done, err := polecatDone(townRoot, rigsConfig, rigName, polecatName)
+			if err != nil {
+				return fmt.Errorf("waiting for %s: %w", entry.Addr(), err)
+			}
+			if !done {
+				allDone = false
+				break
+			}
+		}
+
+		if allDone {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for wave to finish", waveTimeout)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// polecatDone reports whether the named polecat has actually finished,
+// i.e. its dependents are safe to spawn. A polecat is done only once
+// it's retired and no longer tracked by the manager - StateQueued
+// (still waiting for spawn capacity, never started) used to pass a
+// looser "!= StateWorking" check here, which let a wave's dependents
+// spawn before their parent had actually run.
+//
+// A crashed polecat is reported as an error rather than left to poll
+// forever: without --respawn (or with the reaper not running), it will
+// never retire on its own, so waitForDone can't just wait it out.
+func polecatDone(townRoot string, rigsConfig *config.RigsConfig, rigName, polecatName string) (bool, error) {
+	r, err := loadRig(townRoot, rigsConfig, rigName)
+	if err != nil {
+		return false, err
+	}
+
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path))
+	pc, err := polecatMgr.Get(polecatName)
+	if err == polecat.ErrPolecatNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("checking polecat %s/%s: %w", rigName, polecatName, err)
+	}
+
+	if pc.State == polecat.StateCrashed {
+		return false, fmt.Errorf("%s/%s crashed", rigName, polecatName)
+	}
+
+	return false, nil
+}
+
+// currentBranch reports the branch HEAD is on in the town's git checkout,
+// so specs.ShouldSpawn can gate master-only/any-branch entries on it.
+func currentBranch(townRoot string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = townRoot
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return "", fmt.Errorf("%s", errMsg)
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,111 @@
+package polecat
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// WorktreeRunner owns the lifecycle of a single git worktree created for
+// a polecat. It exists so that every path that creates or removes a
+// polecat worktree - spawn, retire, a failed Add, `gt polecat gc` -
+// can `defer runner.Close()` (or call it directly for an adopted
+// worktree) and be sure it's torn down the same, correct way, rather
+// than each call site reimplementing `git worktree remove` + `prune`
+// on its own (modeled on kustomize's releasing gitRunner).
+type WorktreeRunner struct {
+	originalGitPath string
+	worktreePath    string
+
+	created bool
+}
+
+// NewWorktreeRunner builds a runner rooted at the rig's main git
+// checkout (the repo `git worktree add` is run from).
+func NewWorktreeRunner(originalGitPath string) *WorktreeRunner {
+	return &WorktreeRunner{originalGitPath: originalGitPath}
+}
+
+// Adopt builds a runner around a worktree that already exists, so
+// Close can be used to tear it down the same way Create's worktrees
+// are - this is what `gt polecat gc` uses to remove an orphaned
+// worktree it discovered via `git worktree list`, instead of
+// reimplementing the removal separately.
+func Adopt(originalGitPath, worktreePath string) *WorktreeRunner {
+	return &WorktreeRunner{
+		originalGitPath: originalGitPath,
+		worktreePath:    worktreePath,
+		created:         true,
+	}
+}
+
+// Create adds a new worktree at worktreePath on branch, recording it so
+// Close knows what to clean up.
+func (r *WorktreeRunner) Create(branch, worktreePath string) error {
+	cmd := exec.Command("git", "worktree", "add", "-b", branch, worktreePath)
+	cmd.Dir = r.originalGitPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	r.worktreePath = worktreePath
+	r.created = true
+
+	return nil
+}
+
+// Close removes the worktree and prunes its metadata from the main
+// repo's worktree list, using `git worktree remove` rather than a bare
+// `os.RemoveAll` so git's own bookkeeping (locked worktrees, the
+// .git/worktrees administrative files) is handled correctly instead of
+// left for a later `prune` to clean up on a best-effort basis. It's
+// safe to call on a zero-value runner (Create never succeeded) and safe
+// to call twice.
+func (r *WorktreeRunner) Close() error {
+	if !r.created {
+		return nil
+	}
+	r.created = false
+
+	cmd := exec.Command("git", "worktree", "remove", "--force", r.worktreePath)
+	cmd.Dir = r.originalGitPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	prune := exec.Command("git", "worktree", "prune")
+	prune.Dir = r.originalGitPath
+
+	var pruneStderr bytes.Buffer
+	prune.Stderr = &pruneStderr
+
+	if err := prune.Run(); err != nil {
+		if errMsg := strings.TrimSpace(pruneStderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// WorktreePath returns the path Create recorded, or "" if Create hasn't
+// been called (or failed).
+func (r *WorktreeRunner) WorktreePath() string {
+	return r.worktreePath
+}
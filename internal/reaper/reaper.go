@@ -0,0 +1,221 @@
+// Package reaper watches over polecat sessions. It runs as part of `gt
+// daemon`: a poller periodically checks every tracked polecat's tmux
+// session for signs of life, maps dead ones back to the polecat they
+// belonged to via the on-disk Registry, and transitions that polecat's
+// state from working to crashed - optionally respawning it with its
+// last context re-injected.
+//
+// `gt daemon` never forks the tmux/Claude process a polecat runs in -
+// `gt spawn` (or a queue promotion) does, in a separate, short-lived
+// invocation - so SIGCHLD isn't available here: it's only delivered to
+// a process for its own children exiting, and polecat sessions aren't
+// children of the daemon. Polling `tmux has-session` is the signal that
+// actually works across processes.
+package reaper
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/scheduler"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// pollInterval is how often the daemon checks tracked polecat sessions
+// for signs of life. A crash can take up to this long to be noticed.
+const pollInterval = 5 * time.Second
+
+// Reaper supervises all polecat sessions across all rigs for one town.
+type Reaper struct {
+	TownRoot   string
+	RigMgr     *rig.Manager
+	RigsConfig *config.RigsConfig
+
+	// Respawn, if true, re-injects the crashed polecat's last spawn
+	// context into a fresh session instead of just marking it crashed.
+	Respawn bool
+}
+
+// New builds a Reaper for the given town.
+func New(townRoot string, rigMgr *rig.Manager, rigsConfig *config.RigsConfig, respawn bool) *Reaper {
+	return &Reaper{
+		TownRoot:   townRoot,
+		RigMgr:     rigMgr,
+		RigsConfig: rigsConfig,
+		Respawn:    respawn,
+	}
+}
+
+// Run polls tracked polecat sessions until stop is closed, reaping any
+// whose tmux session has disappeared. It's meant to be the main loop of
+// `gt daemon`.
+func (re *Reaper) Run(stop <-chan struct{}) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			re.poll()
+		}
+	}
+}
+
+// poll loads the registry and reaps every tracked polecat whose tmux
+// session is no longer running.
+func (re *Reaper) poll() {
+	reg, err := LoadRegistry(re.TownRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reaper: loading registry: %v\n", err)
+		return
+	}
+
+	for _, rec := range reg.Live {
+		if tmuxSessionAlive(rec.Polecat) {
+			continue
+		}
+		re.reap(rec.Rig, rec.Polecat)
+	}
+}
+
+// reap marks a polecat whose tmux session has disappeared as crashed
+// and, if enabled, respawns it.
+func (re *Reaper) reap(rigName, polecatName string) {
+	reg, err := LoadRegistry(re.TownRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reaper: loading registry: %v\n", err)
+		return
+	}
+
+	exit, ok := reg.ReapByPolecat(rigName, polecatName, false)
+	if !ok {
+		// Already reaped on a previous tick.
+		return
+	}
+
+	if err := reg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "reaper: saving registry: %v\n", err)
+	}
+
+	r, err := re.RigMgr.GetRig(exit.Rig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reaper: rig %s not found for exited polecat %s: %v\n", exit.Rig, exit.Polecat, err)
+		return
+	}
+
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path))
+	if err := polecatMgr.SetState(exit.Polecat, polecat.StateCrashed); err != nil {
+		fmt.Fprintf(os.Stderr, "reaper: marking %s/%s crashed: %v\n", exit.Rig, exit.Polecat, err)
+		return
+	}
+
+	fmt.Printf("reaper: %s/%s's tmux session is gone, marked crashed\n", exit.Rig, exit.Polecat)
+
+	if re.Respawn {
+		if err := re.respawn(r, polecatMgr, exit); err != nil {
+			fmt.Fprintf(os.Stderr, "reaper: respawning %s/%s: %v\n", exit.Rig, exit.Polecat, err)
+		}
+	}
+}
+
+// respawn re-creates a fresh worktree for a crashed polecat and injects
+// its last context again, so a crash becomes a hiccup rather than lost
+// work assignment. It deliberately does not try to resume mid-task state
+// beyond that context - see the checkpoint/restore work for full state
+// recovery.
+//
+// Respawning still has to respect the same max_working capacity the
+// scheduler enforces on a fresh `gt spawn` - otherwise a batch of
+// flapping polecats under `--respawn` blows straight past it, which is
+// exactly the "melts a laptop" scenario the scheduler exists to
+// prevent. Over capacity, this queues the polecat instead of starting
+// it, the same way spawnOne does.
+func (re *Reaper) respawn(r *rig.Rig, polecatMgr *polecat.Manager, exit ExitRecord) error {
+	if err := polecatMgr.Remove(exit.Polecat, true); err != nil {
+		return fmt.Errorf("removing crashed worktree: %w", err)
+	}
+
+	if _, err := polecatMgr.Add(exit.Polecat); err != nil {
+		return fmt.Errorf("recreating worktree: %w", err)
+	}
+
+	context := "[RESTORE] Your previous session crashed and has been respawned.\n\n" + exit.Context
+
+	hasCapacity, err := scheduler.HasCapacity(re.TownRoot, re.RigsConfig, re.RigMgr, exit.Rig)
+	if err != nil {
+		return fmt.Errorf("checking scheduler capacity: %w", err)
+	}
+	if !hasCapacity {
+		if err := polecatMgr.SetState(exit.Polecat, polecat.StateQueued); err != nil {
+			return fmt.Errorf("marking respawned polecat queued: %w", err)
+		}
+
+		q, err := scheduler.Load(re.TownRoot)
+		if err != nil {
+			return err
+		}
+		q.Enqueue(scheduler.Entry{
+			Rig:      exit.Rig,
+			Polecat:  exit.Polecat,
+			Priority: 0,
+			Context:  context,
+		})
+		if err := q.Save(); err != nil {
+			return err
+		}
+
+		fmt.Printf("reaper: %s/%s at capacity - queued respawned polecat\n", exit.Rig, exit.Polecat)
+		return nil
+	}
+
+	if err := polecatMgr.SetState(exit.Polecat, polecat.StateWorking); err != nil {
+		return fmt.Errorf("marking respawned polecat working: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	sessMgr := session.NewManager(t, r)
+	if err := sessMgr.Start(exit.Polecat, session.StartOptions{}); err != nil {
+		return fmt.Errorf("starting session: %w", err)
+	}
+	// Claude needs a few seconds to reach a prompt before it can accept
+	// injected text - same wait spawnOne and startQueuedEntry use.
+	time.Sleep(5 * time.Second)
+
+	if err := sessMgr.Inject(exit.Polecat, context); err != nil {
+		return fmt.Errorf("injecting context: %w", err)
+	}
+
+	reg, err := LoadRegistry(re.TownRoot)
+	if err != nil {
+		return err
+	}
+	reg.MarkRespawned(exit.Rig, exit.Polecat)
+	if pid, pidErr := tmuxPanePID(exit.Polecat); pidErr == nil {
+		reg.Track(exit.Rig, exit.Polecat, pid, exit.Context)
+	}
+	if err := reg.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("reaper: respawned %s/%s\n", exit.Rig, exit.Polecat)
+	return nil
+}
+
+// tmuxSessionAlive reports whether a tmux session named for the given
+// polecat currently exists.
+func tmuxSessionAlive(polecatName string) bool {
+	cmd := exec.Command("tmux", "has-session", "-t", polecatName)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	return cmd.Run() == nil
+}
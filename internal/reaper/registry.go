@@ -0,0 +1,187 @@
+package reaper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Record tracks one polecat session, so the daemon's poller knows which
+// tmux session to check for liveness and can map a dead one back to the
+// polecat it belonged to.
+type Record struct {
+	Rig       string    `json:"rig"`
+	Polecat   string    `json:"polecat"`
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	// Context is the last context injected into the polecat's session
+	// (the [SPAWN]/[RESTORE] message), kept so the daemon can re-inject
+	// it into a fresh session if the polecat crashes and is respawned.
+	Context string `json:"context,omitempty"`
+}
+
+// ExitRecord is kept after a tracked process exits, so `gt reap <polecat>`
+// has something to show even after the process is long gone.
+type ExitRecord struct {
+	Record
+	ExitedAt  time.Time `json:"exited_at"`
+	ExitCode  int       `json:"exit_code"`
+	Respawned bool      `json:"respawned"`
+}
+
+// Registry is the on-disk mapping of live polecat PIDs to the polecat
+// they belong to, plus a log of recent exits. It's shared between
+// whichever process called Track (normally `gt spawn`) and the `gt
+// daemon` process that polls them for liveness, so it lives under
+// mayor/ rather than in memory.
+type Registry struct {
+	path string
+
+	mu     sync.Mutex
+	Live   map[int]Record `json:"live"`
+	Exited []ExitRecord   `json:"exited"`
+}
+
+// registryPath is mayor/reaper/registry.json under the town root.
+func registryPath(townRoot string) string {
+	return filepath.Join(townRoot, "mayor", "reaper", "registry.json")
+}
+
+// LoadRegistry reads the registry from disk, returning an empty one if
+// it doesn't exist yet.
+func LoadRegistry(townRoot string) (*Registry, error) {
+	path := registryPath(townRoot)
+
+	r := &Registry{path: path, Live: make(map[int]Record)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading reaper registry: %w", err)
+	}
+
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("parsing reaper registry: %w", err)
+	}
+	if r.Live == nil {
+		r.Live = make(map[int]Record)
+	}
+	r.path = path
+
+	return r, nil
+}
+
+// Save writes the registry back to disk.
+func (r *Registry) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating reaper dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling reaper registry: %w", err)
+	}
+
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+// Track records that pid is the OS process backing rig/polecat's
+// session, along with the context that was injected into it.
+func (r *Registry) Track(rigName, polecatName string, pid int, context string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Live[pid] = Record{
+		Rig:       rigName,
+		Polecat:   polecatName,
+		PID:       pid,
+		StartedAt: time.Now(),
+		Context:   context,
+	}
+}
+
+// ReapByPolecat removes the live record for rig/polecat and appends an
+// exit record for it. It looks the record up by rig/polecat rather than
+// pid: the daemon never forked these processes, so it has no wait()
+// status for them, only the fact that their tmux session is gone. It
+// reports false if rig/polecat wasn't tracked (e.g. already reaped on a
+// previous poll).
+func (r *Registry) ReapByPolecat(rigName, polecatName string, respawned bool) (ExitRecord, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for pid, rec := range r.Live {
+		if rec.Rig != rigName || rec.Polecat != polecatName {
+			continue
+		}
+		delete(r.Live, pid)
+
+		exit := ExitRecord{
+			Record:   rec,
+			ExitedAt: time.Now(),
+			// ExitCode is unknown - we detected the exit by polling for a
+			// missing tmux session, not by waiting on the process.
+			ExitCode:  -1,
+			Respawned: respawned,
+		}
+
+		// Keep a bounded tail of exit history; nobody needs more than this
+		// to answer "did my polecat crash and did it respawn".
+		const maxExited = 200
+		r.Exited = append(r.Exited, exit)
+		if len(r.Exited) > maxExited {
+			r.Exited = r.Exited[len(r.Exited)-maxExited:]
+		}
+
+		return exit, true
+	}
+
+	return ExitRecord{}, false
+}
+
+// MarkRespawned flags the most recent exit record for rig/polecat as
+// having been respawned.
+func (r *Registry) MarkRespawned(rigName, polecatName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.Exited) - 1; i >= 0; i-- {
+		if r.Exited[i].Rig == rigName && r.Exited[i].Polecat == polecatName {
+			r.Exited[i].Respawned = true
+			return
+		}
+	}
+}
+
+// ByPolecat finds the live record (if any) and most recent exit record
+// (if any) for a given rig/polecat.
+func (r *Registry) ByPolecat(rigName, polecatName string) (live *Record, lastExit *ExitRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rec := range r.Live {
+		if rec.Rig == rigName && rec.Polecat == polecatName {
+			rec := rec
+			live = &rec
+			break
+		}
+	}
+
+	for i := len(r.Exited) - 1; i >= 0; i-- {
+		if r.Exited[i].Rig == rigName && r.Exited[i].Polecat == polecatName {
+			e := r.Exited[i]
+			lastExit = &e
+			break
+		}
+	}
+
+	return live, lastExit
+}
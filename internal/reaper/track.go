@@ -0,0 +1,53 @@
+package reaper
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// TrackSession records the OS PID backing a polecat's tmux session in the
+// shared registry, so `gt daemon` knows to poll that session for
+// liveness. It's called once the session has been started and its
+// context injected.
+func TrackSession(townRoot, rigName, polecatName, tmuxSession, context string) error {
+	pid, err := tmuxPanePID(tmuxSession)
+	if err != nil {
+		return fmt.Errorf("finding pid for session %s: %w", tmuxSession, err)
+	}
+
+	reg, err := LoadRegistry(townRoot)
+	if err != nil {
+		return err
+	}
+
+	reg.Track(rigName, polecatName, pid, context)
+
+	return reg.Save()
+}
+
+// tmuxPanePID returns the PID of the first pane in the named tmux session.
+func tmuxPanePID(session string) (int, error) {
+	cmd := exec.Command("tmux", "list-panes", "-t", session, "-F", "#{pane_pid}")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return 0, fmt.Errorf("%s", errMsg)
+		}
+		return 0, err
+	}
+
+	line := strings.TrimSpace(strings.SplitN(stdout.String(), "\n", 2)[0])
+	pid, err := strconv.Atoi(line)
+	if err != nil {
+		return 0, fmt.Errorf("parsing pane pid %q: %w", line, err)
+	}
+
+	return pid, nil
+}
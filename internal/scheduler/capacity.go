@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// defaultMaxWorking is used when a rig (or the town as a whole) doesn't
+// set "max_working" in rigs.json - unbounded, matching the historical
+// behavior of spawn before capacity limits existed.
+const defaultMaxWorking = 0
+
+// HasCapacity reports whether rigName has room for one more working
+// polecat, respecting both that rig's own max_working and the town-wide
+// total across every rig.
+func HasCapacity(townRoot string, rigsConfig *config.RigsConfig, rigMgr *rig.Manager, rigName string) (bool, error) {
+	entry := rigsConfig.Rigs[rigName]
+
+	rigWorking, totalWorking, err := countWorking(rigsConfig, rigMgr, rigName)
+	if err != nil {
+		return false, err
+	}
+
+	if entry.MaxWorking > defaultMaxWorking && rigWorking >= entry.MaxWorking {
+		return false, nil
+	}
+	if rigsConfig.MaxWorking > defaultMaxWorking && totalWorking >= rigsConfig.MaxWorking {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// countWorking returns the number of working polecats on rigName and
+// across the whole town.
+func countWorking(rigsConfig *config.RigsConfig, rigMgr *rig.Manager, rigName string) (rigWorking, totalWorking int, err error) {
+	for name := range rigsConfig.Rigs {
+		r, err := rigMgr.GetRig(name)
+		if err != nil {
+			continue
+		}
+
+		polecatMgr := polecat.NewManager(r, git.NewGit(r.Path))
+		pcs, err := polecatMgr.List()
+		if err != nil {
+			continue
+		}
+
+		for _, pc := range pcs {
+			if pc.State != polecat.StateWorking {
+				continue
+			}
+			totalWorking++
+			if name == rigName {
+				rigWorking++
+			}
+		}
+	}
+
+	return rigWorking, totalWorking, nil
+}
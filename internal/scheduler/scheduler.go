@@ -0,0 +1,129 @@
+// Package scheduler enforces per-rig and global working-polecat capacity
+// limits, queuing spawns that would exceed them instead of starting a
+// session for every spawn unconditionally.
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Entry is one queued spawn, waiting for capacity to free up.
+type Entry struct {
+	Rig      string    `json:"rig"`
+	Polecat  string    `json:"polecat"`
+	Issue    string    `json:"issue,omitempty"`
+	Priority int       `json:"priority"`
+	Context  string    `json:"context"`
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// Queue is the on-disk FIFO/priority queue of spawns waiting on
+// capacity. Like the reaper registry, it lives under mayor/ so it's
+// shared between the `gt spawn` invocation that enqueues an entry and
+// the `gt daemon` process that promotes it later.
+type Queue struct {
+	path string
+
+	mu      sync.Mutex
+	Paused  bool    `json:"paused"`
+	Entries []Entry `json:"entries"`
+}
+
+func queuePath(townRoot string) string {
+	return filepath.Join(townRoot, "mayor", "scheduler", "queue.json")
+}
+
+// Load reads the queue from disk, returning an empty one if it doesn't
+// exist yet.
+func Load(townRoot string) (*Queue, error) {
+	path := queuePath(townRoot)
+
+	q := &Queue{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading scheduler queue: %w", err)
+	}
+
+	if err := json.Unmarshal(data, q); err != nil {
+		return nil, fmt.Errorf("parsing scheduler queue: %w", err)
+	}
+	q.path = path
+
+	return q, nil
+}
+
+// Save writes the queue back to disk.
+func (q *Queue) Save() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(q.path), 0o755); err != nil {
+		return fmt.Errorf("creating scheduler dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling scheduler queue: %w", err)
+	}
+
+	return os.WriteFile(q.path, data, 0o644)
+}
+
+// Enqueue adds an entry, keeping the queue ordered by ascending priority
+// (P0 is most urgent, matching the P0/P1/P2/P3 convention used
+// everywhere else - see buildSpawnContext's "Priority: P%d") and,
+// within a priority, FIFO by queue time.
+func (q *Queue) Enqueue(e Entry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e.QueuedAt = time.Now()
+	q.Entries = append(q.Entries, e)
+
+	sort.SliceStable(q.Entries, func(i, j int) bool {
+		return q.Entries[i].Priority < q.Entries[j].Priority
+	})
+}
+
+// Pop removes and returns the entry for rig/polecat, if queued.
+func (q *Queue) Pop(rigName, polecatName string) (Entry, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, e := range q.Entries {
+		if e.Rig == rigName && e.Polecat == polecatName {
+			q.Entries = append(q.Entries[:i], q.Entries[i+1:]...)
+			return e, true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// List returns a copy of the queued entries in priority order.
+func (q *Queue) List() []Entry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	out := make([]Entry, len(q.Entries))
+	copy(out, q.Entries)
+	return out
+}
+
+// SetPaused pauses or resumes promotion of queued entries. Spawns still
+// queue while paused - only the background promotion loop stops.
+func (q *Queue) SetPaused(paused bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.Paused = paused
+}
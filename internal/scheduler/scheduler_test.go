@@ -0,0 +1,95 @@
+package scheduler
+
+import "testing"
+
+func TestEnqueueOrdersByAscendingPriority(t *testing.T) {
+	q := &Queue{}
+	q.Enqueue(Entry{Rig: "r", Polecat: "b", Priority: 2})
+	q.Enqueue(Entry{Rig: "r", Polecat: "a", Priority: 0})
+	q.Enqueue(Entry{Rig: "r", Polecat: "c", Priority: 1})
+
+	got := q.List()
+	want := []string{"a", "c", "b"}
+	if len(got) != len(want) {
+		t.Fatalf("List() len = %d, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if e.Polecat != want[i] {
+			t.Errorf("List()[%d].Polecat = %q, want %q", i, e.Polecat, want[i])
+		}
+	}
+}
+
+func TestEnqueueIsFIFOWithinPriority(t *testing.T) {
+	q := &Queue{}
+	q.Enqueue(Entry{Rig: "r", Polecat: "first", Priority: 1})
+	q.Enqueue(Entry{Rig: "r", Polecat: "second", Priority: 1})
+
+	got := q.List()
+	if len(got) != 2 || got[0].Polecat != "first" || got[1].Polecat != "second" {
+		t.Fatalf("List() = %+v, want [first, second]", got)
+	}
+}
+
+func TestPopRemovesMatchingEntry(t *testing.T) {
+	q := &Queue{}
+	q.Enqueue(Entry{Rig: "r", Polecat: "a", Priority: 0})
+	q.Enqueue(Entry{Rig: "r", Polecat: "b", Priority: 0})
+
+	e, ok := q.Pop("r", "a")
+	if !ok {
+		t.Fatal("Pop() ok = false, want true")
+	}
+	if e.Polecat != "a" {
+		t.Errorf("Pop() returned %q, want %q", e.Polecat, "a")
+	}
+
+	if _, ok := q.Pop("r", "a"); ok {
+		t.Error("Pop() of an already-popped entry ok = true, want false")
+	}
+
+	remaining := q.List()
+	if len(remaining) != 1 || remaining[0].Polecat != "b" {
+		t.Errorf("List() after Pop = %+v, want only b", remaining)
+	}
+}
+
+func TestSetPausedDoesNotAffectEntries(t *testing.T) {
+	q := &Queue{}
+	q.Enqueue(Entry{Rig: "r", Polecat: "a", Priority: 0})
+
+	q.SetPaused(true)
+	if !q.Paused {
+		t.Error("Paused = false after SetPaused(true)")
+	}
+	if len(q.List()) != 1 {
+		t.Errorf("List() len = %d after SetPaused(true), want 1", len(q.List()))
+	}
+
+	q.SetPaused(false)
+	if q.Paused {
+		t.Error("Paused = true after SetPaused(false)")
+	}
+}
+
+func TestLoadSaveRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() on empty dir: %v", err)
+	}
+	q.Enqueue(Entry{Rig: "r", Polecat: "a", Priority: 3})
+	if err := q.Save(); err != nil {
+		t.Fatalf("Save(): %v", err)
+	}
+
+	reloaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() after Save: %v", err)
+	}
+	got := reloaded.List()
+	if len(got) != 1 || got[0].Rig != "r" || got[0].Polecat != "a" || got[0].Priority != 3 {
+		t.Errorf("reloaded entries = %+v, want one entry {r a 3}", got)
+	}
+}
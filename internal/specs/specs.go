@@ -0,0 +1,166 @@
+// Package specs parses declarative spawn specs: a JSON file describing a
+// whole swarm of polecats to spawn in one go, with dependencies between
+// them resolved as a DAG.
+package specs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Trigger names when a polecat in a spec should actually be spawned.
+type Trigger string
+
+const (
+	// OnDemand polecats are only spawned when the spec is run explicitly.
+	OnDemand Trigger = "on-demand"
+	// Nightly polecats are intended to be driven by a scheduled job.
+	Nightly Trigger = "nightly"
+	// AnyBranch polecats spawn regardless of which branch HEAD is on.
+	AnyBranch Trigger = "any-branch"
+	// MasterOnly polecats only spawn when the branch passed to ShouldSpawn
+	// (the town checkout's current branch) is named main or master.
+	MasterOnly Trigger = "master-only"
+)
+
+// Entry describes a single polecat to spawn as part of a spec.
+type Entry struct {
+	Rig       string   `json:"rig"`
+	Polecat   string   `json:"polecat,omitempty"`
+	Issue     string   `json:"issue,omitempty"`
+	Task      string   `json:"task,omitempty"`
+	Priority  int      `json:"priority,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty"`
+	Trigger   Trigger  `json:"trigger,omitempty"`
+}
+
+// Addr returns the entry's "rig/polecat" address, or just "rig" if no
+// polecat name was given (spawn will generate one).
+func (e Entry) Addr() string {
+	if e.Polecat == "" {
+		return e.Rig
+	}
+	return e.Rig + "/" + e.Polecat
+}
+
+// Spec is a whole fan-out of polecats, as loaded from a spawns.json file.
+type Spec struct {
+	Polecats []Entry `json:"polecats"`
+}
+
+// Load reads and parses a spec file. Only JSON is supported today; a
+// spawns.yaml form is on the roadmap but not implemented yet.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+
+	var s Spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing spec %s: %w", path, err)
+	}
+
+	for i, e := range s.Polecats {
+		if e.Rig == "" {
+			return nil, fmt.Errorf("spec entry %d: missing rig", i)
+		}
+		if e.Issue == "" && e.Task == "" {
+			return nil, fmt.Errorf("spec entry %d (%s): must set issue or task", i, e.Addr())
+		}
+	}
+
+	return &s, nil
+}
+
+// Waves resolves the dependency DAG described by each entry's DependsOn
+// (polecat names, matched against other entries' Addr()/Polecat) into
+// ordered waves: every entry in wave N only depends on entries in waves
+// < N, so all entries within a wave can be spawned in parallel.
+func Waves(entries []Entry) ([][]Entry, error) {
+	byName := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		key := e.Polecat
+		if key == "" {
+			key = e.Addr()
+		}
+		byName[key] = e
+	}
+
+	resolved := make(map[string]bool, len(entries))
+	var waves [][]Entry
+
+	remaining := append([]Entry(nil), entries...)
+	for len(remaining) > 0 {
+		var wave []Entry
+		var next []Entry
+
+		for _, e := range remaining {
+			ready := true
+			for _, dep := range e.DependsOn {
+				if _, ok := byName[dep]; !ok {
+					return nil, fmt.Errorf("%s depends on unknown polecat %q", e.Addr(), dep)
+				}
+				if !resolved[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, e)
+			} else {
+				next = append(next, e)
+			}
+		}
+
+		if len(wave) == 0 {
+			return nil, fmt.Errorf("spec has a dependency cycle involving %s", next[0].Addr())
+		}
+
+		for _, e := range wave {
+			key := e.Polecat
+			if key == "" {
+				key = e.Addr()
+			}
+			resolved[key] = true
+		}
+
+		waves = append(waves, wave)
+		remaining = next
+	}
+
+	return waves, nil
+}
+
+// ShouldSpawn reports whether an entry's trigger permits spawning it for
+// this invocation of `gt spawn --from-spec`: nightly gates on the
+// --nightly flag, on-demand (the default for an entry with no trigger
+// set) is its complement, and any-branch/master-only gate on the
+// current branch instead. A dependent of a skipped entry still counts
+// that entry as "done" via polecatDone's ErrPolecatNotFound check, so
+// skipping one doesn't block the rest of the spec.
+func ShouldSpawn(e Entry, branch string, nightly bool) bool {
+	switch e.Trigger {
+	case Nightly:
+		return nightly
+	case AnyBranch:
+		return true
+	case MasterOnly:
+		return branch == "main" || branch == "master"
+	case OnDemand, "":
+		return !nightly
+	default:
+		return true
+	}
+}
+
+// Substitute replaces `<(VAR)` placeholders (e.g. `<(ISSUE)`, `<(POLECAT)`)
+// in a task message with the given values.
+func Substitute(message string, vars map[string]string) string {
+	for k, v := range vars {
+		message = strings.ReplaceAll(message, fmt.Sprintf("<(%s)", k), v)
+	}
+	return message
+}
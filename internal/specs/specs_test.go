@@ -0,0 +1,105 @@
+package specs
+
+import "testing"
+
+func TestWavesOrdersByDependency(t *testing.T) {
+	entries := []Entry{
+		{Rig: "r", Polecat: "a"},
+		{Rig: "r", Polecat: "b", DependsOn: []string{"a"}},
+		{Rig: "r", Polecat: "c", DependsOn: []string{"a", "b"}},
+	}
+
+	waves, err := Waves(entries)
+	if err != nil {
+		t.Fatalf("Waves(): %v", err)
+	}
+	if len(waves) != 3 {
+		t.Fatalf("len(waves) = %d, want 3", len(waves))
+	}
+	if len(waves[0]) != 1 || waves[0][0].Polecat != "a" {
+		t.Errorf("wave 0 = %+v, want just a", waves[0])
+	}
+	if len(waves[1]) != 1 || waves[1][0].Polecat != "b" {
+		t.Errorf("wave 1 = %+v, want just b", waves[1])
+	}
+	if len(waves[2]) != 1 || waves[2][0].Polecat != "c" {
+		t.Errorf("wave 2 = %+v, want just c", waves[2])
+	}
+}
+
+func TestWavesParallelizesIndependentEntries(t *testing.T) {
+	entries := []Entry{
+		{Rig: "r", Polecat: "a"},
+		{Rig: "r", Polecat: "b"},
+	}
+
+	waves, err := Waves(entries)
+	if err != nil {
+		t.Fatalf("Waves(): %v", err)
+	}
+	if len(waves) != 1 || len(waves[0]) != 2 {
+		t.Fatalf("waves = %+v, want one wave of 2", waves)
+	}
+}
+
+func TestWavesRejectsUnknownDependency(t *testing.T) {
+	entries := []Entry{
+		{Rig: "r", Polecat: "a", DependsOn: []string{"ghost"}},
+	}
+
+	if _, err := Waves(entries); err == nil {
+		t.Fatal("Waves() err = nil, want error for unknown dependency")
+	}
+}
+
+func TestWavesRejectsCycle(t *testing.T) {
+	entries := []Entry{
+		{Rig: "r", Polecat: "a", DependsOn: []string{"b"}},
+		{Rig: "r", Polecat: "b", DependsOn: []string{"a"}},
+	}
+
+	if _, err := Waves(entries); err == nil {
+		t.Fatal("Waves() err = nil, want error for dependency cycle")
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	got := Substitute("work on <(ISSUE) as <(POLECAT)", map[string]string{
+		"ISSUE":   "gt-1",
+		"POLECAT": "Nux",
+	})
+	want := "work on gt-1 as Nux"
+	if got != want {
+		t.Errorf("Substitute() = %q, want %q", got, want)
+	}
+}
+
+func TestShouldSpawn(t *testing.T) {
+	tests := []struct {
+		name    string
+		trigger Trigger
+		branch  string
+		nightly bool
+		want    bool
+	}{
+		{"on-demand runs on a manual invocation", OnDemand, "feature", false, true},
+		{"on-demand skips a nightly run", OnDemand, "feature", true, false},
+		{"unset trigger defaults to on-demand", "", "feature", false, true},
+		{"nightly skips a manual invocation", Nightly, "feature", false, false},
+		{"nightly runs on a scheduled run", Nightly, "feature", true, true},
+		{"any-branch always runs", AnyBranch, "feature", false, true},
+		{"master-only skips a feature branch", MasterOnly, "feature", false, false},
+		{"master-only runs on master", MasterOnly, "master", false, true},
+		{"master-only runs on main", MasterOnly, "main", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Entry{Trigger: tt.trigger}
+			if got := ShouldSpawn(e, tt.branch, tt.nightly); got != tt.want {
+				t.Errorf("ShouldSpawn(trigger=%q, branch=%q, nightly=%v) = %v, want %v",
+					tt.trigger, tt.branch, tt.nightly, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// BeadsTracker implements Tracker by shelling out to the `bd` CLI. It is
+// the default backend and the one Gas Town shipped with before trackers
+// became pluggable.
+type BeadsTracker struct {
+	RigPath string
+}
+
+func (t *BeadsTracker) FetchIssue(id string) (*Issue, error) {
+	cmd := exec.Command("bd", "show", id, "--json")
+	cmd.Dir = t.RigPath
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+		return nil, err
+	}
+
+	// bd show --json returns an array, take the first element.
+	var issues []Issue
+	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+		return nil, fmt.Errorf("parsing issue: %w", err)
+	}
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("issue not found: %s", id)
+	}
+
+	return &issues[0], nil
+}
+
+func (t *BeadsTracker) Init(worktree string) error {
+	cmd := exec.Command("bd", "init")
+	cmd.Dir = worktree
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (t *BeadsTracker) AssignIssue(id, assignee string) error {
+	cmd := exec.Command("bd", "update", id, "--assignee", assignee)
+	cmd.Dir = t.RigPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (t *BeadsTracker) MarkDone(id, commit string) error {
+	cmd := exec.Command("bd", "close", id, "--commit", commit)
+	cmd.Dir = t.RigPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
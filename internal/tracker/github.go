@@ -0,0 +1,109 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GitHubTracker implements Tracker on top of the `gh` CLI, so teams that
+// file work as GitHub Issues don't need a beads database to use spawn.
+type GitHubTracker struct {
+	RigPath string
+}
+
+type ghIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+func (t *GitHubTracker) FetchIssue(id string) (*Issue, error) {
+	num := strings.TrimPrefix(id, "GH-")
+	cmd := exec.Command("gh", "issue", "view", num, "--json", "number,title,body,state,labels")
+	cmd.Dir = t.RigPath
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+		return nil, err
+	}
+
+	var gi ghIssue
+	if err := json.Unmarshal(stdout.Bytes(), &gi); err != nil {
+		return nil, fmt.Errorf("parsing issue: %w", err)
+	}
+
+	issueType := "task"
+	if len(gi.Labels) > 0 {
+		issueType = gi.Labels[0].Name
+	}
+
+	return &Issue{
+		ID:          "GH-" + strconv.Itoa(gi.Number),
+		Title:       gi.Title,
+		Description: gi.Body,
+		// GitHub Issues have no native priority field, unlike beads/Jira.
+		// Default to P2 (the same fallback jiraPriority uses for an
+		// unrecognized label) rather than leaving the zero value, which
+		// would read as P0 - most urgent - to every priority-aware consumer
+		// (buildSpawnContext, scheduler.Queue.Enqueue).
+		Priority: 2,
+		Type:     issueType,
+		Status:   gi.State,
+	}, nil
+}
+
+// Init is a no-op: a GitHub repo is ready to track issues the moment it
+// has a remote, nothing to initialize per-worktree.
+func (t *GitHubTracker) Init(worktree string) error {
+	return nil
+}
+
+func (t *GitHubTracker) AssignIssue(id, assignee string) error {
+	num := strings.TrimPrefix(id, "GH-")
+	cmd := exec.Command("gh", "issue", "edit", num, "--add-assignee", assignee)
+	cmd.Dir = t.RigPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (t *GitHubTracker) MarkDone(id, commit string) error {
+	num := strings.TrimPrefix(id, "GH-")
+	cmd := exec.Command("gh", "issue", "close", num, "--comment", fmt.Sprintf("Closed by %s", commit))
+	cmd.Dir = t.RigPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GitLabTracker implements Tracker on top of the `glab` CLI.
+type GitLabTracker struct {
+	RigPath string
+}
+
+type glIssue struct {
+	IID         int    `json:"iid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+func (t *GitLabTracker) FetchIssue(id string) (*Issue, error) {
+	iid := strings.TrimPrefix(id, "GL-")
+	cmd := exec.Command("glab", "issue", "view", iid, "--output", "json")
+	cmd.Dir = t.RigPath
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+		return nil, err
+	}
+
+	var gi glIssue
+	if err := json.Unmarshal(stdout.Bytes(), &gi); err != nil {
+		return nil, fmt.Errorf("parsing issue: %w", err)
+	}
+
+	return &Issue{
+		ID:          "GL-" + strconv.Itoa(gi.IID),
+		Title:       gi.Title,
+		Description: gi.Description,
+		// GitLab issues have no native priority field either - same P2
+		// default as GitHubTracker, for the same reason.
+		Priority: 2,
+		Type:     "task",
+		Status:   gi.State,
+	}, nil
+}
+
+// Init is a no-op for GitLab, same as GitHub.
+func (t *GitLabTracker) Init(worktree string) error {
+	return nil
+}
+
+func (t *GitLabTracker) AssignIssue(id, assignee string) error {
+	iid := strings.TrimPrefix(id, "GL-")
+	cmd := exec.Command("glab", "issue", "update", iid, "--assignee", assignee)
+	cmd.Dir = t.RigPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (t *GitLabTracker) MarkDone(id, commit string) error {
+	iid := strings.TrimPrefix(id, "GL-")
+	cmd := exec.Command("glab", "issue", "close", iid, "--note", fmt.Sprintf("Closed by %s", commit))
+	cmd.Dir = t.RigPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errMsg := strings.TrimSpace(stderr.String()); errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+
+	return nil
+}
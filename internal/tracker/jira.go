@@ -0,0 +1,156 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// JiraTracker implements Tracker against the Jira Cloud REST API. Unlike
+// the beads/GitHub/GitLab backends it has no CLI to shell out to, so it
+// talks to the API directly using basic auth.
+type JiraTracker struct {
+	RigPath  string
+	BaseURL  string
+	Email    string
+	APIToken string
+
+	client *http.Client
+}
+
+// NewJiraTracker builds a JiraTracker from the JIRA_BASE_URL, JIRA_EMAIL,
+// and JIRA_API_TOKEN environment variables.
+func NewJiraTracker(rigPath string) (*JiraTracker, error) {
+	baseURL := os.Getenv("JIRA_BASE_URL")
+	email := os.Getenv("JIRA_EMAIL")
+	token := os.Getenv("JIRA_API_TOKEN")
+	if baseURL == "" || email == "" || token == "" {
+		return nil, fmt.Errorf("jira tracker requires JIRA_BASE_URL, JIRA_EMAIL, and JIRA_API_TOKEN")
+	}
+
+	return &JiraTracker{
+		RigPath:  rigPath,
+		BaseURL:  baseURL,
+		Email:    email,
+		APIToken: token,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		IssueType   struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Status struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		Priority struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+	} `json:"fields"`
+}
+
+func (t *JiraTracker) FetchIssue(id string) (*Issue, error) {
+	var ji jiraIssue
+	if err := t.do("GET", "/rest/api/2/issue/"+id, nil, &ji); err != nil {
+		return nil, fmt.Errorf("fetching jira issue %s: %w", id, err)
+	}
+
+	return &Issue{
+		ID:          ji.Key,
+		Title:       ji.Fields.Summary,
+		Description: ji.Fields.Description,
+		Priority:    jiraPriority(ji.Fields.Priority.Name),
+		Type:        ji.Fields.IssueType.Name,
+		Status:      ji.Fields.Status.Name,
+	}, nil
+}
+
+// jiraPriority maps Jira's default priority scheme onto the P0 (most
+// urgent) .. P4 numbering used everywhere else an Issue.Priority is
+// read (buildSpawnContext, the scheduler's queue ordering). Projects
+// with a custom priority scheme will need their own mapping here -
+// anything unrecognized lands at P2, the scheme's own default.
+func jiraPriority(name string) int {
+	switch name {
+	case "Highest":
+		return 0
+	case "High":
+		return 1
+	case "Medium":
+		return 2
+	case "Low":
+		return 3
+	case "Lowest":
+		return 4
+	default:
+		return 2
+	}
+}
+
+// Init is a no-op: Jira projects are provisioned outside the worktree
+// lifecycle.
+func (t *JiraTracker) Init(worktree string) error {
+	return nil
+}
+
+func (t *JiraTracker) AssignIssue(id, assignee string) error {
+	body := map[string]any{"fields": map[string]any{"assignee": map[string]any{"name": assignee}}}
+	if err := t.do("PUT", "/rest/api/2/issue/"+id, body, nil); err != nil {
+		return fmt.Errorf("assigning jira issue %s: %w", id, err)
+	}
+	return nil
+}
+
+func (t *JiraTracker) MarkDone(id, commit string) error {
+	body := map[string]any{"body": fmt.Sprintf("Closed by %s", commit)}
+	if err := t.do("POST", "/rest/api/2/issue/"+id+"/comment", body, nil); err != nil {
+		return fmt.Errorf("commenting on jira issue %s: %w", id, err)
+	}
+	// Transitioning to "Done" is project-workflow specific, so we leave
+	// the actual state change to the comment + whatever automation the
+	// team has wired up on it.
+	return nil
+}
+
+func (t *JiraTracker) do(method, path string, reqBody, respBody any) error {
+	var r io.Reader
+	if reqBody != nil {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, t.BaseURL+path, r)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(t.Email, t.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("jira returned %s: %s", resp.Status, string(b))
+	}
+
+	if respBody != nil {
+		return json.NewDecoder(resp.Body).Decode(respBody)
+	}
+	return nil
+}
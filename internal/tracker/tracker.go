@@ -0,0 +1,65 @@
+// Package tracker abstracts the issue tracker a rig is wired up to, so
+// spawn and friends don't have to know whether a team files work in
+// beads, GitHub Issues, GitLab, or Jira.
+package tracker
+
+import "fmt"
+
+// Issue is a tracker-agnostic view of a unit of work. Backends translate
+// their native representation (a beads JSON row, a GitHub issue, a Jira
+// ticket) into this shape.
+type Issue struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Priority    int    `json:"priority"`
+	Type        string `json:"issue_type"`
+	Status      string `json:"status"`
+}
+
+// Tracker is the interface a polecat's issue-tracker backend must
+// implement. Implementations shell out or call REST APIs; callers don't
+// need to care which.
+type Tracker interface {
+	// FetchIssue retrieves the issue with the given ID.
+	FetchIssue(id string) (*Issue, error)
+	// Init prepares the tracker for use in a freshly created worktree
+	// (e.g. `bd init`). It is not fatal for callers if this fails - most
+	// backends are no-ops here.
+	Init(worktree string) error
+	// AssignIssue assigns the issue to the given assignee (typically a
+	// polecat name).
+	AssignIssue(id, assignee string) error
+	// MarkDone marks the issue as complete, recording the commit that
+	// closed it.
+	MarkDone(id, commit string) error
+}
+
+// Name identifies a supported tracker backend, as written in rigs.json
+// under a rig's "tracker" key.
+type Name string
+
+const (
+	Beads  Name = "beads"
+	GitHub Name = "github"
+	GitLab Name = "gitlab"
+	Jira   Name = "jira"
+)
+
+// New constructs the Tracker backend named by n for the given rig path.
+// An empty name defaults to beads, preserving the historical behavior of
+// spawn before trackers were pluggable.
+func New(n Name, rigPath string) (Tracker, error) {
+	switch n {
+	case "", Beads:
+		return &BeadsTracker{RigPath: rigPath}, nil
+	case GitHub:
+		return &GitHubTracker{RigPath: rigPath}, nil
+	case GitLab:
+		return &GitLabTracker{RigPath: rigPath}, nil
+	case Jira:
+		return NewJiraTracker(rigPath)
+	default:
+		return nil, fmt.Errorf("unknown tracker backend: %q", n)
+	}
+}